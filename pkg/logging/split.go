@@ -0,0 +1,52 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewSplit creates a new Logger that writes warnings and more severe
+// entries to high and all other entries to low.
+func NewSplit(low, high io.Writer, level logrus.Level) Logger {
+	l := New(io.Discard, level).(*logger)
+	l.AddHook(splitHook{low: low, high: high})
+	return l
+}
+
+// StdoutStderrSplit creates a new Logger that writes warnings and
+// errors to the standard error and all other entries to the
+// standard output.
+func StdoutStderrSplit(level logrus.Level) Logger {
+	return NewSplit(os.Stdout, os.Stderr, level)
+}
+
+// splitHook writes the formatted entries to one of two writers,
+// depending on the entry level. Logrus fires the hooks with the
+// logger lock held, so the writes are not interleaved.
+type splitHook struct {
+	low  io.Writer
+	high io.Writer
+}
+
+func (h splitHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h splitHook) Fire(e *logrus.Entry) error {
+	b, err := e.Bytes()
+	if err != nil {
+		return err
+	}
+	w := h.low
+	if e.Level <= logrus.WarnLevel {
+		w = h.high
+	}
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/logging"
+	"github.com/sirupsen/logrus"
+)
+
+func TestNewSplit(t *testing.T) {
+	var low, high bytes.Buffer
+	logger := logging.NewSplit(&low, &high, logrus.InfoLevel)
+
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Warning("warning message")
+	logger.Error("error message")
+
+	for _, tc := range []struct {
+		name     string
+		buf      *bytes.Buffer
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "low",
+			buf:      &low,
+			want:     []string{"info message"},
+			dontWant: []string{"debug message", "warning message", "error message"},
+		},
+		{
+			name:     "high",
+			buf:      &high,
+			want:     []string{"warning message", "error message"},
+			dontWant: []string{"debug message", "info message"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.buf.String()
+			for _, s := range tc.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("got %q, want %q", got, s)
+				}
+			}
+			for _, s := range tc.dontWant {
+				if strings.Contains(got, s) {
+					t.Errorf("got %q, don't want %q", got, s)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,13 @@
 
 package soc
 
+import (
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
 var (
 	ErrInvalidAddress = errInvalidAddress
 	Hash              = hash
@@ -24,3 +31,25 @@ func (s *SOC) OwnerAddress() []byte {
 func (s *SOC) ID() []byte {
 	return s.id
 }
+
+func SetTimeNow(f func() time.Time) {
+	timeNow = f
+}
+
+func SetNewTicker(f func(d time.Duration) (<-chan time.Time, func())) {
+	newTicker = f
+}
+
+func LenientDowngradedCount() prometheus.Counter {
+	return socMetrics.LenientDowngradedCount
+}
+
+// SetValidate replaces the validation measured by the timer.
+func (t *Timer) SetValidate(f func(swarm.Chunk) error) {
+	t.validate = f
+}
+
+// CachedOwner returns the owner cached by Owner or Valid.
+func (p Parts) CachedOwner() []byte {
+	return p.decoded.soc.owner
+}
@@ -0,0 +1,340 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc
+
+import (
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/logging"
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+const (
+	// defaults
+	defaultInspectorWindow = 10 * time.Minute
+	defaultMaxOwners       = 10
+	defaultMaxExamples     = 5
+
+	// maxTrackedOwners bounds the number of distinct owners counted
+	// in a single window. Rejections by owners seen after the limit
+	// is reached are only accounted in Report.OtherOwners.
+	maxTrackedOwners = 1024
+)
+
+// Rejection reasons used as keys in Report.
+const (
+	ReasonChunkSize       = "chunk_size"
+	ReasonWrappedChunk    = "wrapped_chunk"
+	ReasonSignature       = "signature"
+	ReasonAddressMismatch = "address_mismatch"
+	ReasonOther           = "other"
+)
+
+var (
+	// timeNow is used to deterministically mock time.Now() in tests.
+	timeNow = time.Now
+
+	// newTicker is used to deterministically mock time.NewTicker() in tests.
+	newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		t := time.NewTicker(d)
+		return t.C, t.Stop
+	}
+)
+
+// InspectorOptions configures an Inspector. Zero values are replaced
+// with defaults.
+type InspectorOptions struct {
+	// Window is the duration covered by a single report.
+	Window time.Duration
+	// MaxOwners is the number of most offending owners kept in a report.
+	MaxOwners int
+	// MaxExamples is the number of example chunk addresses kept per
+	// rejection reason in a report.
+	MaxExamples int
+	// Logger, if set, is used to log every report when its window ends.
+	Logger logging.Logger
+}
+
+// OwnerCount is the number of rejected chunks of a single owner.
+type OwnerCount struct {
+	Owner string `json:"owner"`
+	Count uint64 `json:"count"`
+}
+
+// Report summarizes validations performed within a time window.
+type Report struct {
+	Start       time.Time                  `json:"start"`
+	End         time.Time                  `json:"end"`
+	Valid       uint64                     `json:"valid"`
+	Rejected    map[string]uint64          `json:"rejected"`
+	TopOwners   []OwnerCount               `json:"topOwners"`
+	OtherOwners uint64                     `json:"otherOwners"`
+	Examples    map[string][]swarm.Address `json:"examples"`
+}
+
+// Inspector validates single-owner chunks and aggregates the
+// rejections into per window reports. It is safe for concurrent use.
+type Inspector struct {
+	window      time.Duration
+	maxOwners   int
+	maxExamples int
+	logger      logging.Logger
+
+	mtx         sync.Mutex
+	start       time.Time
+	valid       uint64
+	rejected    map[string]uint64
+	owners      map[string]uint64
+	otherOwners uint64
+	examples    map[string][]swarm.Address
+	last        Report
+
+	quit      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewInspector creates a new Inspector. If a logger is given,
+// reports are also logged on every window interval until Close is called.
+func NewInspector(o InspectorOptions) *Inspector {
+	i := &Inspector{
+		window:      o.Window,
+		maxOwners:   o.MaxOwners,
+		maxExamples: o.MaxExamples,
+		logger:      o.Logger,
+		quit:        make(chan struct{}),
+	}
+
+	if o.Window <= 0 {
+		i.window = defaultInspectorWindow
+	}
+
+	if o.MaxOwners <= 0 {
+		i.maxOwners = defaultMaxOwners
+	}
+
+	if o.MaxExamples <= 0 {
+		i.maxExamples = defaultMaxExamples
+	}
+
+	i.reset(timeNow())
+
+	if i.logger != nil {
+		i.wg.Add(1)
+		go i.run()
+	}
+
+	return i
+}
+
+// Valid checks if the chunk is a valid single-owner chunk
+// and records the result.
+func (i *Inspector) Valid(ch swarm.Chunk) bool {
+	return i.ValidE(ch) == nil
+}
+
+// ValidE checks if the chunk is a valid single-owner chunk, records
+// the result and returns the same error as the package level ValidE.
+func (i *Inspector) ValidE(ch swarm.Chunk) error {
+	owner, err := validate(ch)
+
+	i.mtx.Lock()
+	r, rotated := i.rotate(timeNow())
+	i.record(ch, owner, err)
+	i.mtx.Unlock()
+
+	if rotated {
+		i.log(r)
+	}
+	return err
+}
+
+// record accounts the result of a single validation.
+// It must be called with the lock held.
+func (i *Inspector) record(ch swarm.Chunk, owner []byte, err error) {
+	if err == nil {
+		i.valid++
+		return
+	}
+
+	reason := rejectionReason(err)
+	i.rejected[reason]++
+
+	if len(i.examples[reason]) < i.maxExamples {
+		i.examples[reason] = append(i.examples[reason], ch.Address())
+	}
+
+	if owner != nil {
+		key := hex.EncodeToString(owner)
+		if _, ok := i.owners[key]; ok || len(i.owners) < maxTrackedOwners {
+			i.owners[key]++
+		} else {
+			i.otherOwners++
+		}
+	}
+}
+
+// Report returns the report of the last completed window. The zero
+// Report is returned if no window has completed yet.
+func (i *Inspector) Report() Report {
+	i.mtx.Lock()
+	r, rotated := i.rotate(timeNow())
+	last := i.last.clone()
+	i.mtx.Unlock()
+
+	if rotated {
+		i.log(r)
+	}
+	return last
+}
+
+// Close stops the periodic logging of reports.
+func (i *Inspector) Close() error {
+	i.closeOnce.Do(func() {
+		close(i.quit)
+	})
+	i.wg.Wait()
+	return nil
+}
+
+func (i *Inspector) run() {
+	defer i.wg.Done()
+
+	tick, stop := newTicker(i.window)
+	defer stop()
+
+	for {
+		select {
+		case <-i.quit:
+			return
+		case <-tick:
+			i.mtx.Lock()
+			r, rotated := i.rotate(timeNow())
+			i.mtx.Unlock()
+
+			if rotated {
+				i.log(r)
+			}
+		}
+	}
+}
+
+// log logs the report of a completed window, if a logger is set.
+// It must not be called with the lock held, so that a slow logger
+// does not block the validations.
+func (i *Inspector) log(r Report) {
+	if i.logger == nil {
+		return
+	}
+	i.logger.Debugf("soc inspector: window %s - %s: valid %d, rejected %v, top owners %v",
+		r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339), r.Valid, r.Rejected, r.TopOwners)
+}
+
+// rotate finalizes the current window if it has ended by now and
+// returns its report. It must be called with the lock held.
+func (i *Inspector) rotate(now time.Time) (Report, bool) {
+	end := i.start.Add(i.window)
+	if now.Before(end) {
+		return Report{}, false
+	}
+
+	i.last = i.report(end)
+	r := i.last.clone()
+
+	// start the next window where the current one ended,
+	// unless more than one whole window has passed since.
+	if now.Sub(end) < i.window {
+		i.reset(end)
+	} else {
+		i.reset(now)
+	}
+	return r, true
+}
+
+// report builds the Report of the current window.
+// It must be called with the lock held.
+func (i *Inspector) report(end time.Time) Report {
+	owners := make([]OwnerCount, 0, len(i.owners))
+	for owner, count := range i.owners {
+		owners = append(owners, OwnerCount{Owner: owner, Count: count})
+	}
+	sort.Slice(owners, func(a, b int) bool {
+		if owners[a].Count != owners[b].Count {
+			return owners[a].Count > owners[b].Count
+		}
+		return owners[a].Owner < owners[b].Owner
+	})
+
+	otherOwners := i.otherOwners
+	if len(owners) > i.maxOwners {
+		for _, o := range owners[i.maxOwners:] {
+			otherOwners += o.Count
+		}
+		owners = owners[:i.maxOwners]
+	}
+
+	return Report{
+		Start:       i.start,
+		End:         end,
+		Valid:       i.valid,
+		Rejected:    i.rejected,
+		TopOwners:   owners,
+		OtherOwners: otherOwners,
+		Examples:    i.examples,
+	}
+}
+
+// clone returns a deep copy of the report.
+func (r Report) clone() Report {
+	c := r
+	if r.Rejected != nil {
+		c.Rejected = make(map[string]uint64, len(r.Rejected))
+		for k, v := range r.Rejected {
+			c.Rejected[k] = v
+		}
+	}
+	if r.TopOwners != nil {
+		c.TopOwners = append([]OwnerCount(nil), r.TopOwners...)
+	}
+	if r.Examples != nil {
+		c.Examples = make(map[string][]swarm.Address, len(r.Examples))
+		for k, v := range r.Examples {
+			c.Examples[k] = append([]swarm.Address(nil), v...)
+		}
+	}
+	return c
+}
+
+// reset starts a new empty window at the given time.
+// It must be called with the lock held.
+func (i *Inspector) reset(start time.Time) {
+	i.start = start
+	i.valid = 0
+	i.rejected = make(map[string]uint64)
+	i.owners = make(map[string]uint64)
+	i.otherOwners = 0
+	i.examples = make(map[string][]swarm.Address)
+}
+
+// rejectionReason maps a validation error to the reason it is
+// reported under.
+func rejectionReason(err error) string {
+	switch {
+	case errors.Is(err, ErrWrongChunkSize):
+		return ReasonChunkSize
+	case errors.Is(err, ErrInvalidWrappedChunk):
+		return ReasonWrappedChunk
+	case errors.Is(err, ErrInvalidSignature):
+		return ReasonSignature
+	case errors.Is(err, ErrAddressMismatch):
+		return ReasonAddressMismatch
+	default:
+		return ReasonOther
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/logging"
+	"github.com/ethersphere/bee/pkg/soc"
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/sirupsen/logrus"
+)
+
+func TestInspector(t *testing.T) {
+	var (
+		mtx sync.Mutex
+		now = time.Unix(1000, 0)
+	)
+	soc.SetTimeNow(func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return now
+	})
+	defer soc.SetTimeNow(time.Now)
+	advance := func(d time.Duration) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		now = now.Add(d)
+	}
+
+	tick := make(chan time.Time)
+	soc.SetNewTicker(func(time.Duration) (<-chan time.Time, func()) {
+		return tick, func() {}
+	})
+	defer soc.SetNewTicker(func(d time.Duration) (<-chan time.Time, func()) {
+		t := time.NewTicker(d)
+		return t.C, t.Stop
+	})
+
+	// waitLogged waits for the periodic logging goroutine
+	// to log the n-th report.
+	buf := new(safeBuffer)
+	waitLogged := func(n int) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if strings.Count(buf.String(), "soc inspector") >= n {
+				return
+			}
+		}
+		t.Fatalf("report %d not logged, got %q", n, buf.String())
+	}
+
+	r := soc.NewInspector(soc.InspectorOptions{
+		Window:      time.Hour,
+		MaxOwners:   1,
+		MaxExamples: 2,
+		Logger:      logging.New(buf, logrus.DebugLevel),
+	})
+	defer r.Close()
+
+	valid, owner1 := signedChunk(t, 1)
+	other, _ := signedChunk(t, 2)
+
+	// owner1 has three chunks with mismatching addresses, owner2 one
+	var mismatched []swarm.Address
+	for j := 0; j < 3; j++ {
+		addr := swarm.NewAddress(bytes.Repeat([]byte{byte(j + 1)}, swarm.HashSize))
+		mismatched = append(mismatched, addr)
+		if r.Valid(swarm.NewChunk(addr, valid.Data())) {
+			t.Fatal("chunk with wrong address evaluates to valid")
+		}
+	}
+	if r.Valid(swarm.NewChunk(swarm.MustParseHexAddress("ff"), other.Data())) {
+		t.Fatal("chunk with wrong address evaluates to valid")
+	}
+
+	var wg sync.WaitGroup
+	for j := 0; j < 10; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !r.Valid(valid) {
+				t.Error("valid chunk evaluates to invalid")
+			}
+			if r.Valid(swarm.NewChunk(valid.Address(), []byte("small"))) {
+				t.Error("small chunk evaluates to valid")
+			}
+		}()
+	}
+	wg.Wait()
+
+	// a tick before the window ended does not complete it
+	tick <- now
+	if rep := r.Report(); !rep.End.IsZero() {
+		t.Fatalf("got report %+v before the window ended", rep)
+	}
+
+	// the logging goroutine completes the window on the next tick
+	advance(time.Hour)
+	tick <- now
+	waitLogged(1)
+	if !strings.Contains(buf.String(), "valid 10") {
+		t.Fatalf("got log %q, want the report", buf.String())
+	}
+
+	rep := r.Report()
+	if !rep.Start.Equal(time.Unix(1000, 0)) || !rep.End.Equal(time.Unix(1000, 0).Add(time.Hour)) {
+		t.Fatalf("got window %v - %v", rep.Start, rep.End)
+	}
+	if rep.Valid != 10 {
+		t.Fatalf("got %d valid, want 10", rep.Valid)
+	}
+	if got := rep.Rejected[soc.ReasonAddressMismatch]; got != 4 {
+		t.Fatalf("got %d address mismatches, want 4", got)
+	}
+	if got := rep.Rejected[soc.ReasonChunkSize]; got != 10 {
+		t.Fatalf("got %d chunk size rejections, want 10", got)
+	}
+	if len(rep.TopOwners) != 1 {
+		t.Fatalf("got %d top owners, want 1", len(rep.TopOwners))
+	}
+	if want := (soc.OwnerCount{Owner: hex.EncodeToString(owner1), Count: 3}); rep.TopOwners[0] != want {
+		t.Fatalf("got top owner %+v, want %+v", rep.TopOwners[0], want)
+	}
+	if rep.OtherOwners != 1 {
+		t.Fatalf("got %d other owners rejections, want 1", rep.OtherOwners)
+	}
+	examples := rep.Examples[soc.ReasonAddressMismatch]
+	if len(examples) != 2 || !examples[0].Equal(mismatched[0]) || !examples[1].Equal(mismatched[1]) {
+		t.Fatalf("got examples %v, want %v", examples, mismatched[:2])
+	}
+	if got := len(rep.Examples[soc.ReasonChunkSize]); got != 2 {
+		t.Fatalf("got %d chunk size examples, want 2", got)
+	}
+
+	// reports returned to callers are copies
+	rep.Rejected[soc.ReasonChunkSize] = 0
+	rep.Examples[soc.ReasonChunkSize][0] = swarm.ZeroAddress
+	rep.TopOwners[0].Count = 0
+	again := r.Report()
+	if again.Rejected[soc.ReasonChunkSize] != 10 || again.Examples[soc.ReasonChunkSize][0].IsZero() || again.TopOwners[0].Count != 3 {
+		t.Fatalf("report modified through a returned copy: %+v", again)
+	}
+
+	// the next window starts empty
+	if !r.Valid(valid) {
+		t.Fatal("valid chunk evaluates to invalid")
+	}
+	advance(time.Hour)
+	tick <- now
+	waitLogged(2)
+
+	rep = r.Report()
+	if rep.Valid != 1 || len(rep.Rejected) != 0 || len(rep.TopOwners) != 0 {
+		t.Fatalf("got report %+v, want a single valid chunk", rep)
+	}
+	if got := strings.Count(buf.String(), "soc inspector"); got != 2 {
+		t.Fatalf("got %d logged reports, want 2", got)
+	}
+
+	// closing twice is safe
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/logging"
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+// Failure is a set of validation failure classes.
+type Failure uint8
+
+const (
+	// FailureNone is the empty set of failure classes.
+	FailureNone Failure = 0
+	// FailureAddressMismatch is the class of chunks with a valid
+	// signature whose address does not match the one derived from
+	// the id and the recovered owner.
+	FailureAddressMismatch Failure = 1 << 0
+)
+
+// lenientWarnInterval is the minimal interval between two
+// warnings about downgraded acceptances.
+const lenientWarnInterval = time.Minute
+
+// lenientWarnings rate limits the warnings about downgraded
+// acceptances, counting the ones that were not logged.
+var lenientWarnings struct {
+	mtx        sync.Mutex
+	last       time.Time
+	suppressed uint64
+}
+
+// LenientPolicy configures ValidLenient.
+type LenientPolicy struct {
+	// Downgrade is the set of failure classes that ValidLenient
+	// accepts with a warning instead of rejecting.
+	//
+	// Only failures detected after the signature has been verified
+	// can be downgraded. Chunks that are too short, that wrap an
+	// invalid chunk or whose owner can not be recovered are always
+	// rejected.
+	Downgrade Failure
+	// Logger, if set, is used to warn about chunks accepted because of
+	// a downgraded failure, at most once per minute. Each warning
+	// reports how many acceptances were not logged since the last one.
+	Logger logging.Logger
+}
+
+// Result is the outcome of a lenient validation.
+type Result struct {
+	// Owner is the ethereum address of the recovered owner. It is
+	// a copy owned by the caller.
+	Owner []byte
+	// Downgraded is the set of failure classes that were
+	// accepted because of the policy. It is empty for chunks
+	// that pass the strict validation.
+	Downgraded Failure
+}
+
+// ValidLenient checks if the chunk is a valid single-owner chunk,
+// accepting the failure classes allowed by the policy. It is intended
+// for bounded protocol transition windows only; Valid and ValidE are
+// not affected by it. Chunks accepted only because of a downgraded
+// failure are counted in the package Metrics and logged as warnings.
+func ValidLenient(ch swarm.Chunk, policy LenientPolicy) (Result, error) {
+	owner, err := validate(ch)
+	// the owner is handed to the caller, keep it apart from the parsed SOC
+	owner = append([]byte(nil), owner...)
+	if err == nil {
+		return Result{Owner: owner}, nil
+	}
+
+	if errors.Is(err, ErrAddressMismatch) && policy.Downgrade&FailureAddressMismatch != 0 {
+		socMetrics.LenientDowngradedCount.Inc()
+		if policy.Logger != nil {
+			warnDowngraded(policy.Logger, ch, err)
+		}
+		return Result{Owner: owner, Downgraded: FailureAddressMismatch}, nil
+	}
+
+	return Result{}, err
+}
+
+// warnDowngraded logs a warning about a chunk accepted despite the
+// failure, unless a warning was logged within lenientWarnInterval.
+func warnDowngraded(logger logging.Logger, ch swarm.Chunk, failure error) {
+	lenientWarnings.mtx.Lock()
+	now := timeNow()
+	if !lenientWarnings.last.IsZero() && now.Sub(lenientWarnings.last) < lenientWarnInterval {
+		lenientWarnings.suppressed++
+		lenientWarnings.mtx.Unlock()
+		return
+	}
+	suppressed := lenientWarnings.suppressed
+	lenientWarnings.last = now
+	lenientWarnings.suppressed = 0
+	lenientWarnings.mtx.Unlock()
+
+	logger.Warningf("soc: lenient validation accepted chunk %s despite %v (%d more accepted since the last warning)", ch.Address(), failure, suppressed)
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/logging"
+	"github.com/ethersphere/bee/pkg/soc"
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+)
+
+func TestValidLenient(t *testing.T) {
+	ch, owner := signedChunk(t, 0)
+
+	chunks := []struct {
+		name  string
+		chunk swarm.Chunk
+	}{
+		{name: "valid", chunk: ch},
+		{name: "small data", chunk: swarm.NewChunk(ch.Address(), []byte("small"))},
+		{name: "invalid signature", chunk: swarm.NewChunk(ch.Address(), corruptSignature(ch.Data()))},
+		{name: "wrong soc address", chunk: swarm.NewChunk(swarm.MustParseHexAddress("aa"), ch.Data())},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		policy soc.LenientPolicy
+		// accepted and downgraded by chunk name
+		accepted   map[string]bool
+		downgraded map[string]soc.Failure
+	}{
+		{
+			name:     "none",
+			policy:   soc.LenientPolicy{},
+			accepted: map[string]bool{"valid": true},
+		},
+		{
+			name:       "address mismatch",
+			policy:     soc.LenientPolicy{Downgrade: soc.FailureAddressMismatch},
+			accepted:   map[string]bool{"valid": true, "wrong soc address": true},
+			downgraded: map[string]soc.Failure{"wrong soc address": soc.FailureAddressMismatch},
+		},
+	} {
+		for _, c := range chunks {
+			t.Run(tc.name+"/"+c.name, func(t *testing.T) {
+				strictErr := soc.ValidE(c.chunk)
+				before := testutil.ToFloat64(soc.LenientDowngradedCount())
+
+				r, err := soc.ValidLenient(c.chunk, tc.policy)
+
+				if got, want := err == nil, tc.accepted[c.name]; got != want {
+					t.Fatalf("policy %v: got accepted %v, want %v (err %v)", tc.policy, got, want, err)
+				}
+				if r.Downgraded != tc.downgraded[c.name] {
+					t.Fatalf("policy %v: got downgraded %v, want %v", tc.policy, r.Downgraded, tc.downgraded[c.name])
+				}
+				if err == nil && !bytes.Equal(r.Owner, owner) {
+					t.Fatalf("policy %v: got owner %x, want %x", tc.policy, r.Owner, owner)
+				}
+				if err != nil && err.Error() != strictErr.Error() {
+					t.Fatalf("policy %v: got error %v, want %v", tc.policy, err, strictErr)
+				}
+
+				var wantCount float64
+				if r.Downgraded != soc.FailureNone {
+					wantCount = 1
+				}
+				if got := testutil.ToFloat64(soc.LenientDowngradedCount()) - before; got != wantCount {
+					t.Fatalf("policy %v: got %v downgraded acceptances, want %v", tc.policy, got, wantCount)
+				}
+
+				// the strict paths are not affected
+				if got := soc.ValidE(c.chunk); (got == nil) != (strictErr == nil) || (got != nil && got.Error() != strictErr.Error()) {
+					t.Fatalf("got strict error %v, want %v", got, strictErr)
+				}
+				if got, want := soc.Valid(c.chunk), strictErr == nil; got != want {
+					t.Fatalf("got strict valid %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+// TestValidLenientWarning verifies that downgraded acceptances
+// are logged as rate limited warnings.
+func TestValidLenientWarning(t *testing.T) {
+	var (
+		mtx sync.Mutex
+		// after any warning logged with the real clock
+		now = time.Now().Add(time.Hour)
+	)
+	soc.SetTimeNow(func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return now
+	})
+	defer soc.SetTimeNow(time.Now)
+	advance := func(d time.Duration) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		now = now.Add(d)
+	}
+
+	ch, _ := signedChunk(t, 0)
+	mismatched := swarm.NewChunk(swarm.MustParseHexAddress("aa"), ch.Data())
+
+	buf := new(safeBuffer)
+	policy := soc.LenientPolicy{
+		Downgrade: soc.FailureAddressMismatch,
+		Logger:    logging.New(buf, logrus.WarnLevel),
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := soc.ValidLenient(mismatched, policy); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// accepted chunks are not warned about
+	if _, err := soc.ValidLenient(ch, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := buf.String()
+	if got := strings.Count(logs, "lenient validation accepted"); got != 1 {
+		t.Fatalf("got %d warnings, want 1: %q", got, logs)
+	}
+	if !strings.Contains(logs, "level=warning") || !strings.Contains(logs, mismatched.Address().String()) {
+		t.Fatalf("got log %q, want a warning about chunk %s", logs, mismatched.Address())
+	}
+
+	advance(time.Minute)
+	if _, err := soc.ValidLenient(mismatched, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	logs = buf.String()
+	if got := strings.Count(logs, "lenient validation accepted"); got != 2 {
+		t.Fatalf("got %d warnings, want 2: %q", got, logs)
+	}
+	if !strings.Contains(logs, "2 more accepted since the last warning") {
+		t.Fatalf("got log %q, want the suppressed count", logs)
+	}
+}
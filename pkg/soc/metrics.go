@@ -0,0 +1,38 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc
+
+import (
+	m "github.com/ethersphere/bee/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// socMetrics holds the metrics of the package level validators.
+var socMetrics = newMetrics()
+
+type metrics struct {
+	// all metrics fields must be exported
+	// to be able to return them by Metrics()
+	// using reflection
+	LenientDowngradedCount prometheus.Counter
+}
+
+func newMetrics() metrics {
+	subsystem := "soc"
+
+	return metrics{
+		LenientDowngradedCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "lenient_downgraded_count",
+			Help:      "Number of chunks accepted by lenient validation only because of a downgraded failure.",
+		}),
+	}
+}
+
+// Metrics returns the prometheus collectors of the package.
+func Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(socMetrics)
+}
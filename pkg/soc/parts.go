@@ -0,0 +1,100 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+// errNotDecoded is returned by the Parts methods if they
+// were not created by Decode.
+var errNotDecoded = errors.New("soc: parts not created by decode")
+
+// Parts holds the parts of a decoded single-owner chunk.
+type Parts struct {
+	// ID is the SOC identifier.
+	ID ID
+	// Signature is the signature of the owner.
+	Signature []byte
+	// WrappedAddress is the address of the wrapped chunk.
+	WrappedAddress swarm.Address
+	// Span is the span of the wrapped chunk.
+	Span uint64
+
+	address swarm.Address
+	decoded *decoded
+}
+
+// decoded is a parsed SOC shared by the copies of Parts,
+// with the lazily recovered owner cached in it.
+type decoded struct {
+	soc  *SOC
+	once sync.Once
+	err  error
+}
+
+// recoverOwner recovers the owner once and caches it in the SOC.
+func (d *decoded) recoverOwner() error {
+	d.once.Do(func() {
+		d.soc.owner, d.err = d.soc.recoverOwner()
+	})
+	return d.err
+}
+
+// Decode parses the single-owner chunk without validating it. Only
+// the chunk size and the wrapped chunk are checked, as the other parts
+// can not be extracted otherwise.
+//
+// The chunk data is copied, so the returned Parts are a consistent
+// snapshot independent of later changes to the chunk.
+func Decode(ch swarm.Chunk) (Parts, error) {
+	data := make([]byte, len(ch.Data()))
+	copy(data, ch.Data())
+
+	s, err := parse(swarm.NewChunk(ch.Address(), data))
+	if err != nil {
+		return Parts{}, err
+	}
+
+	return Parts{
+		ID:             append(ID(nil), s.id...),
+		Signature:      append([]byte(nil), s.signature...),
+		WrappedAddress: s.chunk.Address(),
+		Span:           binary.LittleEndian.Uint64(s.chunk.Data()[:swarm.SpanSize]),
+		address:        ch.Address(),
+		decoded:        &decoded{soc: s},
+	}, nil
+}
+
+// Owner returns the ethereum address of the owner recovered from
+// the signature. The owner is recovered only on the first call of
+// Owner or Valid.
+func (p Parts) Owner() ([]byte, error) {
+	if p.decoded == nil {
+		return nil, errNotDecoded
+	}
+	if err := p.decoded.recoverOwner(); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), p.decoded.soc.owner...), nil
+}
+
+// Valid completes the validation of the decoded chunk, reusing
+// the already parsed parts and the owner if it was recovered
+// before. It returns the same error as ValidE.
+func (p Parts) Valid() error {
+	if p.decoded == nil {
+		return errNotDecoded
+	}
+	if err := p.decoded.recoverOwner(); err != nil {
+		return err
+	}
+	_, err := validateParsed(p.address, p.decoded.soc)
+	return err
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/cac"
+	"github.com/ethersphere/bee/pkg/soc"
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+func TestDecode(t *testing.T) {
+	ch, owner := signedChunk(t, 0)
+
+	wrapped, err := cac.New([]byte{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts, err := soc.Decode(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parts.ID, make([]byte, swarm.HashSize)) {
+		t.Fatalf("got id %x, want zero id", parts.ID)
+	}
+	if !bytes.Equal(parts.Signature, ch.Data()[swarm.HashSize:swarm.HashSize+swarm.SocSignatureSize]) {
+		t.Fatalf("got signature %x", parts.Signature)
+	}
+	if !parts.WrappedAddress.Equal(wrapped.Address()) {
+		t.Fatalf("got wrapped address %s, want %s", parts.WrappedAddress, wrapped.Address())
+	}
+	if parts.Span != 1 {
+		t.Fatalf("got span %d, want 1", parts.Span)
+	}
+	gotOwner, err := parts.Owner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gotOwner, owner) {
+		t.Fatalf("got owner %x, want %x", gotOwner, owner)
+	}
+}
+
+// TestDecodeValid verifies that Decode followed by Parts.Valid
+// reports the same result as ValidE.
+func TestDecodeValid(t *testing.T) {
+	ch, _ := signedChunk(t, 0)
+
+	for _, tc := range []struct {
+		name string
+		// decodeErr is the expected error of Decode, as opposed
+		// to the one of ValidE reported by Parts.Valid
+		decodeErr bool
+		chunk     swarm.Chunk
+	}{
+		{
+			name:  "valid",
+			chunk: ch,
+		},
+		{
+			name:      "small data",
+			decodeErr: true,
+			chunk:     swarm.NewChunk(ch.Address(), []byte("small")),
+		},
+		{
+			name:      "large data",
+			decodeErr: true,
+			chunk:     swarm.NewChunk(ch.Address(), []byte(strings.Repeat("a", swarm.ChunkSize+swarm.SocMinChunkSize+1))),
+		},
+		{
+			name:  "invalid signature",
+			chunk: swarm.NewChunk(ch.Address(), corruptSignature(ch.Data())),
+		},
+		{
+			name:  "wrong soc address",
+			chunk: swarm.NewChunk(swarm.MustParseHexAddress("aa"), ch.Data()),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := soc.ValidE(tc.chunk)
+
+			parts, err := soc.Decode(tc.chunk)
+			if tc.decodeErr {
+				if err == nil || err.Error() != want.Error() {
+					t.Fatalf("got decode error %v, want %v", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := parts.Valid()
+			if (got == nil) != (want == nil) || (got != nil && got.Error() != want.Error()) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestDecodeOwnerCached verifies that the owner is recovered
+// once and shared by Owner and Valid.
+func TestDecodeOwnerCached(t *testing.T) {
+	ch, owner := signedChunk(t, 0)
+
+	parts, err := soc.Decode(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parts.CachedOwner() != nil {
+		t.Fatal("owner recovered by decode")
+	}
+
+	got, err := parts.Owner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parts.CachedOwner(), owner) {
+		t.Fatalf("got cached owner %x, want %x", parts.CachedOwner(), owner)
+	}
+
+	// the returned owner is a copy
+	got[0]++
+	if err := parts.Valid(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := parts.Owner(); !bytes.Equal(got, owner) {
+		t.Fatalf("got owner %x, want %x", got, owner)
+	}
+}
+
+// TestDecodeSnapshot verifies that Parts do not change
+// with the data of the decoded chunk.
+func TestDecodeSnapshot(t *testing.T) {
+	ch, owner := signedChunk(t, 0)
+	data := make([]byte, len(ch.Data()))
+	copy(data, ch.Data())
+	ch = swarm.NewChunk(ch.Address(), data)
+
+	parts, err := soc.Decode(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := append([]byte(nil), parts.ID...)
+	sig := append([]byte(nil), parts.Signature...)
+
+	for i := range data {
+		data[i]++
+	}
+	if soc.Valid(ch) {
+		t.Fatal("changed chunk evaluates to valid")
+	}
+
+	if !bytes.Equal(parts.ID, id) || !bytes.Equal(parts.Signature, sig) {
+		t.Fatal("parts changed with the chunk data")
+	}
+	if err := parts.Valid(); err != nil {
+		t.Fatalf("got error %v for the decoded snapshot", err)
+	}
+	if got, _ := parts.Owner(); !bytes.Equal(got, owner) {
+		t.Fatalf("got owner %x, want %x", got, owner)
+	}
+}
+
+func TestPartsZero(t *testing.T) {
+	var parts soc.Parts
+	if _, err := parts.Owner(); err == nil {
+		t.Fatal("expected error for owner of zero parts")
+	}
+	if err := parts.Valid(); err == nil {
+		t.Fatal("expected error for validation of zero parts")
+	}
+}
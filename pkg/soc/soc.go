@@ -17,7 +17,6 @@ import (
 
 var (
 	errInvalidAddress = errors.New("soc: invalid address")
-	errWrongChunkSize = errors.New("soc: chunk length is less than minimum")
 )
 
 // ID is a SOC identifier
@@ -117,9 +116,25 @@ func (s *SOC) Sign(signer crypto.Signer) (swarm.Chunk, error) {
 
 // FromChunk recreates a SOC representation from swarm.Chunk data.
 func FromChunk(sch swarm.Chunk) (*SOC, error) {
+	s, err := parse(sch)
+	if err != nil {
+		return nil, err
+	}
+
+	s.owner, err = s.recoverOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// parse splits the swarm.Chunk data into the SOC fields
+// without recovering the owner from the signature.
+func parse(sch swarm.Chunk) (*SOC, error) {
 	chunkData := sch.Data()
 	if len(chunkData) < swarm.SocMinChunkSize {
-		return nil, errWrongChunkSize
+		return nil, ErrWrongChunkSize
 	}
 
 	// add all the data fields to the SOC
@@ -134,10 +149,17 @@ func FromChunk(sch swarm.Chunk) (*SOC, error) {
 
 	ch, err := cac.NewWithDataSpan(chunkData[cursor:])
 	if err != nil {
-		return nil, err
+		return nil, &validationError{class: ErrInvalidWrappedChunk, cause: err}
 	}
+	s.chunk = ch
+
+	return s, nil
+}
 
-	toSignBytes, err := hash(s.id, ch.Address().Bytes())
+// recoverOwner returns the ethereum address of the owner
+// recovered from the signature of the parsed SOC.
+func (s *SOC) recoverOwner() ([]byte, error) {
+	toSignBytes, err := hash(s.id, s.chunk.Address().Bytes())
 	if err != nil {
 		return nil, err
 	}
@@ -145,15 +167,13 @@ func FromChunk(sch swarm.Chunk) (*SOC, error) {
 	// recover owner information
 	recoveredOwnerAddress, err := recoverAddress(s.signature, toSignBytes)
 	if err != nil {
-		return nil, err
+		return nil, &validationError{class: ErrInvalidSignature, cause: err}
 	}
 	if len(recoveredOwnerAddress) != crypto.AddressSize {
-		return nil, errInvalidAddress
+		return nil, &validationError{class: ErrInvalidSignature, cause: errInvalidAddress}
 	}
-	s.owner = recoveredOwnerAddress
-	s.chunk = ch
 
-	return s, nil
+	return recoveredOwnerAddress, nil
 }
 
 // CreateAddress creates a new SOC address from the id and
@@ -0,0 +1,144 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/logging"
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+// histogramBuckets is the number of latency buckets. The upper
+// bound of bucket i is 2^i microseconds, the last one is unbounded.
+const histogramBuckets = 32
+
+// TimedValidE checks if the chunk is a valid single-owner chunk like
+// ValidE does and also returns the time the validation took.
+func TimedValidE(ch swarm.Chunk) (time.Duration, error) {
+	return timed(ValidE, ch)
+}
+
+// timed calls validate on the chunk and returns the time it took.
+func timed(validate func(swarm.Chunk) error, ch swarm.Chunk) (time.Duration, error) {
+	start := timeNow()
+	err := validate(ch)
+	return timeNow().Sub(start), err
+}
+
+// TimerOptions configures a Timer.
+type TimerOptions struct {
+	// Threshold is the validation duration above which chunks are
+	// logged as warnings. Zero disables the logging.
+	Threshold time.Duration
+	// Logger is used to log slow chunks.
+	Logger logging.Logger
+}
+
+// Stats summarizes the validation latencies recorded by a Timer.
+// Percentiles are approximated by the upper bound of the histogram
+// bucket they fall into.
+type Stats struct {
+	Count uint64        `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	Max   time.Duration `json:"max"`
+}
+
+// Timer validates single-owner chunks and records the validation
+// latencies into a bounded histogram. It is safe for concurrent use.
+type Timer struct {
+	threshold time.Duration
+	logger    logging.Logger
+	validate  func(swarm.Chunk) error
+
+	mtx     sync.Mutex
+	buckets [histogramBuckets]uint64
+	count   uint64
+	max     time.Duration
+}
+
+// NewTimer creates a new Timer.
+func NewTimer(o TimerOptions) *Timer {
+	return &Timer{
+		threshold: o.Threshold,
+		logger:    o.Logger,
+		validate:  ValidE,
+	}
+}
+
+// ValidE checks if the chunk is a valid single-owner chunk,
+// records the validation latency and returns the same error
+// as the package level ValidE.
+func (t *Timer) ValidE(ch swarm.Chunk) error {
+	d, err := timed(t.validate, ch)
+	t.record(d)
+
+	if t.logger != nil && t.threshold > 0 && d > t.threshold {
+		t.logger.Warningf("soc: slow validation of chunk %s took %s", ch.Address(), d)
+	}
+	return err
+}
+
+// Stats returns the statistics of all recorded validations.
+func (t *Timer) Stats() Stats {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return Stats{
+		Count: t.count,
+		P50:   t.percentile(0.5),
+		P95:   t.percentile(0.95),
+		Max:   t.max,
+	}
+}
+
+func (t *Timer) record(d time.Duration) {
+	i := 0
+	for i < histogramBuckets-1 && d > bucketBound(i) {
+		i++
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.buckets[i]++
+	t.count++
+	if d > t.max {
+		t.max = d
+	}
+}
+
+// percentile returns the upper bound of the bucket holding the
+// q-th quantile, never more than the maximum recorded duration.
+// It must be called with the lock held.
+func (t *Timer) percentile(q float64) time.Duration {
+	if t.count == 0 {
+		return 0
+	}
+
+	rank := uint64(q*float64(t.count) + 0.5)
+	if rank == 0 {
+		rank = 1
+	}
+
+	var seen uint64
+	for i, n := range t.buckets {
+		seen += n
+		if seen >= rank {
+			if b := bucketBound(i); i < histogramBuckets-1 && b < t.max {
+				return b
+			}
+			return t.max
+		}
+	}
+	return t.max
+}
+
+// bucketBound returns the upper bound of the i-th histogram bucket.
+func bucketBound(i int) time.Duration {
+	return time.Microsecond << uint(i)
+}
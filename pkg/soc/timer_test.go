@@ -0,0 +1,139 @@
+// Copyright 2022 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package soc_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/logging"
+	"github.com/ethersphere/bee/pkg/soc"
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/sirupsen/logrus"
+)
+
+// TestTimerSlowValidation verifies that the real validation latency
+// is measured and logged, by injecting a validation that sleeps.
+func TestTimerSlowValidation(t *testing.T) {
+	const (
+		delay     = 100 * time.Millisecond
+		threshold = 20 * time.Millisecond
+	)
+
+	ch, _ := signedChunk(t, 0)
+	slow, _ := signedChunk(t, 1)
+
+	buf := new(safeBuffer)
+	timer := soc.NewTimer(soc.TimerOptions{
+		Threshold: threshold,
+		Logger:    logging.New(buf, logrus.WarnLevel),
+	})
+	timer.SetValidate(func(c swarm.Chunk) error {
+		if c.Address().Equal(slow.Address()) {
+			time.Sleep(delay)
+		}
+		return soc.ValidE(c)
+	})
+
+	for _, c := range []swarm.Chunk{ch, slow} {
+		if err := timer.ValidE(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := timer.Stats()
+	if s.Count != 2 {
+		t.Fatalf("got count %d, want 2", s.Count)
+	}
+	if s.Max < delay {
+		t.Fatalf("got max %v, want at least %v", s.Max, delay)
+	}
+
+	logs := buf.String()
+	if got := strings.Count(logs, "slow validation"); got != 1 {
+		t.Fatalf("got %d slow validation logs, want 1: %q", got, logs)
+	}
+	if !strings.Contains(logs, "level=warning") || !strings.Contains(logs, slow.Address().String()) {
+		t.Fatalf("got logs %q, want a warning about chunk %s", logs, slow.Address())
+	}
+}
+
+func TestTimer(t *testing.T) {
+	var (
+		mtx  sync.Mutex
+		now  = time.Unix(1000, 0)
+		step time.Duration
+	)
+	// every reading of the clock advances it by step, so
+	// each validation takes exactly step
+	soc.SetTimeNow(func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		now = now.Add(step)
+		return now
+	})
+	defer soc.SetTimeNow(time.Now)
+	setStep := func(d time.Duration) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		step = d
+	}
+
+	ch, _ := signedChunk(t, 0)
+	slow, _ := signedChunk(t, 1)
+
+	buf := new(safeBuffer)
+	timer := soc.NewTimer(soc.TimerOptions{
+		Threshold: time.Millisecond,
+		Logger:    logging.New(buf, logrus.WarnLevel),
+	})
+
+	if s := timer.Stats(); s != (soc.Stats{}) {
+		t.Fatalf("got stats %+v, want zero", s)
+	}
+
+	setStep(3 * time.Microsecond)
+	for i := 0; i < 18; i++ {
+		if err := timer.ValidE(ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setStep(5 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := timer.ValidE(slow); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := soc.Stats{
+		Count: 20,
+		P50:   4 * time.Microsecond,
+		P95:   5 * time.Millisecond,
+		Max:   5 * time.Millisecond,
+	}
+	if s := timer.Stats(); s != want {
+		t.Fatalf("got stats %+v, want %+v", s, want)
+	}
+
+	logs := buf.String()
+	if got := strings.Count(logs, "slow validation"); got != 2 {
+		t.Fatalf("got %d slow validation logs, want 2: %q", got, logs)
+	}
+	if !strings.Contains(logs, slow.Address().String()) || strings.Contains(logs, ch.Address().String()) {
+		t.Fatalf("got logs %q, want only chunk %s", logs, slow.Address())
+	}
+
+	// invalid chunks are timed too
+	setStep(time.Microsecond)
+	d, err := soc.TimedValidE(swarm.NewChunk(ch.Address(), []byte("small")))
+	if err == nil {
+		t.Fatal("small chunk evaluates to valid")
+	}
+	if d != time.Microsecond {
+		t.Fatalf("got duration %v, want %v", d, time.Microsecond)
+	}
+}
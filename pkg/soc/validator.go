@@ -5,19 +5,89 @@
 package soc
 
 import (
+	"errors"
+
 	"github.com/ethersphere/bee/pkg/swarm"
 )
 
+// Errors returned by ValidE. Each one identifies the class of
+// the check that rejected the chunk and may be wrapped with
+// more detail, so they should be compared with errors.Is.
+var (
+	// ErrWrongChunkSize is returned when the chunk data is too
+	// short to hold the SOC id, signature and wrapped chunk span.
+	ErrWrongChunkSize = errors.New("soc: chunk length is less than minimum")
+	// ErrInvalidWrappedChunk is returned when the wrapped
+	// content-addressed chunk can not be constructed.
+	ErrInvalidWrappedChunk = errors.New("soc: invalid wrapped chunk")
+	// ErrInvalidSignature is returned when the owner can not be
+	// recovered from the signature.
+	ErrInvalidSignature = errors.New("soc: invalid signature")
+	// ErrAddressMismatch is returned when the chunk address does
+	// not match the one derived from the id and the recovered owner.
+	ErrAddressMismatch = errors.New("soc: address mismatch")
+)
+
+// validationError is the error of a failed check. It matches the
+// class of the check with errors.Is and unwraps to its cause.
+type validationError struct {
+	class error
+	cause error
+}
+
+func (e *validationError) Error() string {
+	return e.class.Error() + ": " + e.cause.Error()
+}
+
+func (e *validationError) Unwrap() error {
+	return e.cause
+}
+
+func (e *validationError) Is(target error) bool {
+	return target == e.class
+}
+
 // Valid checks if the chunk is a valid single-owner chunk.
 func Valid(ch swarm.Chunk) bool {
-	s, err := FromChunk(ch)
+	return ValidE(ch) == nil
+}
+
+// ValidE checks if the chunk is a valid single-owner chunk and
+// returns an error describing the reason if it is not.
+func ValidE(ch swarm.Chunk) error {
+	_, err := validate(ch)
+	return err
+}
+
+// validate checks if the chunk is a valid single-owner chunk.
+// It returns the recovered owner whenever the signature could be
+// verified, even if the chunk was rejected for its address.
+func validate(ch swarm.Chunk) (owner []byte, err error) {
+	s, err := parse(ch)
 	if err != nil {
-		return false
+		return nil, err
+	}
+	return validateParsed(ch.Address(), s)
+}
+
+// validateParsed completes the validation of a parsed SOC
+// expected to have the given address. The owner is recovered
+// from the signature unless it is already set.
+func validateParsed(addr swarm.Address, s *SOC) (owner []byte, err error) {
+	owner = s.owner
+	if owner == nil {
+		owner, err = s.recoverOwner()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	address, err := s.address()
+	address, err := CreateAddress(s.id, owner)
 	if err != nil {
-		return false
+		return nil, err
+	}
+	if !addr.Equal(address) {
+		return owner, ErrAddressMismatch
 	}
-	return ch.Address().Equal(address)
+	return owner, nil
 }
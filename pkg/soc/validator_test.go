@@ -5,9 +5,14 @@
 package soc_test
 
 import (
+	"bytes"
+	"errors"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/ethersphere/bee/pkg/cac"
+	"github.com/ethersphere/bee/pkg/crypto"
 	"github.com/ethersphere/bee/pkg/soc"
 	"github.com/ethersphere/bee/pkg/swarm"
 )
@@ -112,3 +117,133 @@ func TestInvalid(t *testing.T) {
 		})
 	}
 }
+
+// TestValidE verifies that ValidE reports the class
+// of the check that rejected the chunk.
+func TestValidE(t *testing.T) {
+	ch, _ := signedChunk(t, 0)
+
+	for _, tc := range []struct {
+		name  string
+		chunk swarm.Chunk
+		want  error
+	}{
+		{
+			name:  "valid",
+			chunk: ch,
+		},
+		{
+			name:  "small data",
+			chunk: swarm.NewChunk(ch.Address(), []byte("small")),
+			want:  soc.ErrWrongChunkSize,
+		},
+		{
+			name:  "large data",
+			chunk: swarm.NewChunk(ch.Address(), []byte(strings.Repeat("a", swarm.ChunkSize+swarm.SocMinChunkSize+1))),
+			want:  soc.ErrInvalidWrappedChunk,
+		},
+		{
+			name:  "invalid signature",
+			chunk: swarm.NewChunk(ch.Address(), corruptSignature(ch.Data())),
+			want:  soc.ErrInvalidSignature,
+		},
+		{
+			name:  "wrong soc address",
+			chunk: swarm.NewChunk(swarm.MustParseHexAddress("aa"), ch.Data()),
+			want:  soc.ErrAddressMismatch,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := soc.ValidE(tc.chunk)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("got error %v, want %v", err, tc.want)
+			}
+			if got, want := soc.Valid(tc.chunk), tc.want == nil; got != want {
+				t.Fatalf("got valid %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestValidECause verifies that the errors returned by ValidE
+// keep the cause of the failed check reachable.
+func TestValidECause(t *testing.T) {
+	data := []byte(strings.Repeat("a", swarm.ChunkSize+swarm.SocMinChunkSize+1))
+	_, cause := cac.NewWithDataSpan(data[swarm.HashSize+swarm.SocSignatureSize:])
+	if cause == nil {
+		t.Fatal("expected wrapped chunk error")
+	}
+
+	err := soc.ValidE(swarm.NewChunk(swarm.ZeroAddress, data))
+	if !errors.Is(err, soc.ErrInvalidWrappedChunk) {
+		t.Fatalf("got error %v, want %v", err, soc.ErrInvalidWrappedChunk)
+	}
+	if !errors.Is(err, cause) {
+		t.Fatalf("got error %v, want it to wrap %v", err, cause)
+	}
+	if errors.Is(err, soc.ErrInvalidSignature) {
+		t.Fatalf("got error %v matching %v", err, soc.ErrInvalidSignature)
+	}
+}
+
+// signedChunk returns a valid soc chunk wrapping
+// a chunk with the given payload byte and its owner.
+func signedChunk(t testing.TB, b byte) (swarm.Chunk, []byte) {
+	t.Helper()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+
+	ch, err := cac.New([]byte{b})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := soc.New(make([]byte, swarm.HashSize), ch)
+	sch, err := s.Sign(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, err := crypto.NewEthereumAddress(privKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sch, owner
+}
+
+// corruptSignature returns a copy of soc chunk data
+// with a signature that can not be recovered.
+func corruptSignature(data []byte) []byte {
+	d := make([]byte, len(data))
+	copy(d, data)
+	// an invalid recovery id
+	d[swarm.HashSize+swarm.SocSignatureSize-1] = 0xff
+	return d
+}
+
+type safeBuffer struct {
+	b   bytes.Buffer
+	mtx sync.Mutex
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *safeBuffer) Len() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.b.Len()
+}
+
+func (b *safeBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.b.String()
+}